@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// headerFlag collects repeatable -header key=value flags into a map,
+// forwarded as custom gRPC/HTTP headers (e.g. an `X-AppKey` or bearer
+// token a managed collector requires).
+type headerFlag map[string]string
+
+func (h headerFlag) String() string {
+	if len(h) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(h))
+	for k, v := range h {
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (h headerFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok {
+		return errors.New("header must be in key=value form")
+	}
+	h[key] = val
+	return nil
+}
@@ -7,22 +7,48 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-
+	logpkg "github.com/yinyin/go-otel-exporter-files/log"
+	metricpkg "github.com/yinyin/go-otel-exporter-files/metric"
 	trace "github.com/yinyin/go-otel-exporter-files/trace"
 )
 
-func parseCommandParam() (otlpEndpoint string, insecureMode bool, targetPaths []string, err error) {
-	flag.StringVar(&otlpEndpoint, "endpoint", "[::1]:4317", "target OTLP gRPC endpoint")
-	flag.BoolVar(&insecureMode, "insecure", false, "allow clear-text connection")
+type commandParam struct {
+	otlpEndpoint    string
+	insecureMode    bool
+	continueOnError bool
+	checkpointDir   string
+	headers         headerFlag
+	compression     string
+	timeout         time.Duration
+	protocol        string
+	caFile          string
+	certFile        string
+	keyFile         string
+	targetPaths     []string
+}
+
+func parseCommandParam() (param commandParam, err error) {
+	param.headers = make(headerFlag)
+	flag.StringVar(&param.otlpEndpoint, "endpoint", "[::1]:4317", "target OTLP endpoint")
+	flag.BoolVar(&param.insecureMode, "insecure", false, "allow clear-text connection")
+	flag.BoolVar(&param.continueOnError, "continue-on-error", false, "keep importing remaining files in a trace folder after one file fails")
+	flag.StringVar(&param.checkpointDir, "checkpoint-dir", "", "directory to record per-file upload checkpoints for resuming a failed trace import")
+	flag.Var(&param.headers, "header", "custom header to send as key=value, repeatable")
+	flag.StringVar(&param.compression, "compression", "", "compression codec for the OTLP wire (e.g. gzip)")
+	flag.DurationVar(&param.timeout, "timeout", 0, "timeout for each OTLP upload RPC (0 = client default)")
+	flag.StringVar(&param.protocol, "protocol", "grpc", "OTLP transport protocol: grpc or http/protobuf")
+	flag.StringVar(&param.caFile, "ca-file", "", "PEM file with CA certificates to verify the collector, for mTLS")
+	flag.StringVar(&param.certFile, "cert-file", "", "PEM file with the client certificate, for mTLS")
+	flag.StringVar(&param.keyFile, "key-file", "", "PEM file with the client private key, for mTLS")
 	flag.Parse()
-	if len(otlpEndpoint) == 0 {
+	if len(param.otlpEndpoint) == 0 {
 		err = errors.New("missing required parameter: endpoint")
 		return
 	}
-	targetPaths = flag.Args()
-	if len(targetPaths) == 0 {
+	param.targetPaths = flag.Args()
+	if len(param.targetPaths) == 0 {
 		err = errors.New("required target paths")
 		return
 	}
@@ -30,35 +56,122 @@ func parseCommandParam() (otlpEndpoint string, insecureMode bool, targetPaths []
 }
 
 func main() {
-	otlpEndpoint, insecureMode, targetPaths, err := parseCommandParam()
+	if (len(os.Args) > 1) && (os.Args[1] == "verify") {
+		runVerify(os.Args[2:])
+		return
+	}
+	param, err := parseCommandParam()
 	if nil != err {
 		log.Fatalf("failed to parse command parameters: %v", err)
 		return
 	}
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
-	otlpTraceClientOpts := make([]otlptracegrpc.Option, 0, 2)
-	if insecureMode {
-		otlpTraceClientOpts = append(otlpTraceClientOpts, otlptracegrpc.WithInsecure())
+
+	tlsConfig, err := loadTLSConfig(param.caFile, param.certFile, param.keyFile)
+	if nil != err {
+		log.Fatalf("failed to load TLS configuration: %v", err)
+		return
+	}
+	clientOpts := otlpClientOptions{
+		endpoint:     param.otlpEndpoint,
+		insecureMode: param.insecureMode,
+		headers:      param.headers,
+		compression:  param.compression,
+		timeout:      param.timeout,
+		tlsConfig:    tlsConfig,
+	}
+
+	traceImportOpts := []trace.ImportOption{
+		trace.WithRetryPolicy(trace.DefaultRetryPolicy),
+		trace.WithContinueOnError(param.continueOnError),
+		trace.WithCheckpointDir(param.checkpointDir),
 	}
-	otlpTraceClientOpts = append(otlpTraceClientOpts, otlptracegrpc.WithEndpoint(otlpEndpoint))
-	client := otlptracegrpc.NewClient(otlpTraceClientOpts...)
-	if err = client.Start(ctx); nil != err {
+
+	traceClient, err := newTraceClient(param.protocol, clientOpts)
+	if nil != err {
+		log.Fatalf("failed to configure OTLP trace client: %v", err)
+		return
+	}
+	if err = traceClient.Start(ctx); nil != err {
 		log.Fatalf("failed to start OTLP trace client: %v", err)
 		return
 	}
-	defer client.Stop(context.Background())
-	for _, targetPath := range targetPaths {
-		if fInfos, err := os.Lstat(targetPath); nil != err {
+	defer traceClient.Stop(context.Background())
+
+	logsClient, err := newGRPCLogsClient(clientOpts)
+	if nil != err {
+		log.Fatalf("failed to start OTLP logs client: %v", err)
+		return
+	}
+	defer logsClient.Close()
+
+	metricsClient, err := newGRPCMetricsClient(clientOpts)
+	if nil != err {
+		log.Fatalf("failed to start OTLP metrics client: %v", err)
+		return
+	}
+	defer metricsClient.Close()
+
+	// The log/metric uploaders only speak raw gRPC (see newGRPCLogsClient /
+	// newGRPCMetricsClient); -protocol http/protobuf only swaps the trace
+	// client's transport. Rather than silently dialing gRPC against what
+	// may be an HTTP-only collector, refuse log/metric targets up front
+	// when a non-grpc protocol was requested.
+	logMetricProtocolSupported := param.protocol == "" || param.protocol == "grpc"
+
+	for _, targetPath := range param.targetPaths {
+		fInfos, err := os.Lstat(targetPath)
+		if nil != err {
 			log.Printf("cannot read meta of target path %q: %v", targetPath, err)
-		} else if fInfos.IsDir() {
-			log.Printf("INFO: import trace folder %q ...", targetPath)
-			trace.ImportTraceFolder(ctx, client, targetPath)
+			continue
+		}
+		if fInfos.IsDir() {
+			switch kind := detectSignalKindOfFolder(targetPath); kind {
+			case signalLog:
+				if !logMetricProtocolSupported {
+					log.Printf("WARN: skip log folder %q: -protocol %q is not supported for log uploads (grpc only)", targetPath, param.protocol)
+					continue
+				}
+				log.Printf("INFO: import log folder %q ...", targetPath)
+				err = logpkg.ImportLogFolder(ctx, logsClient, targetPath)
+			case signalMetric:
+				if !logMetricProtocolSupported {
+					log.Printf("WARN: skip metric folder %q: -protocol %q is not supported for metric uploads (grpc only)", targetPath, param.protocol)
+					continue
+				}
+				log.Printf("INFO: import metric folder %q ...", targetPath)
+				err = metricpkg.ImportMetricFolder(ctx, metricsClient, targetPath)
+			default:
+				log.Printf("INFO: import trace folder %q ...", targetPath)
+				err = trace.ImportTraceFolder(ctx, traceClient, targetPath, traceImportOpts...)
+			}
 		} else if fInfos.Mode().IsRegular() {
-			log.Printf("INFO: import trace file %q ...", targetPath)
-			trace.ImportTraceFile(ctx, client, targetPath)
+			switch kind := detectSignalKindOfFile(targetPath); kind {
+			case signalLog:
+				if !logMetricProtocolSupported {
+					log.Printf("WARN: skip log file %q: -protocol %q is not supported for log uploads (grpc only)", targetPath, param.protocol)
+					continue
+				}
+				log.Printf("INFO: import log file %q ...", targetPath)
+				err = logpkg.ImportLogFile(ctx, logsClient, targetPath)
+			case signalMetric:
+				if !logMetricProtocolSupported {
+					log.Printf("WARN: skip metric file %q: -protocol %q is not supported for metric uploads (grpc only)", targetPath, param.protocol)
+					continue
+				}
+				log.Printf("INFO: import metric file %q ...", targetPath)
+				err = metricpkg.ImportMetricFile(ctx, metricsClient, targetPath)
+			default:
+				log.Printf("INFO: import trace file %q ...", targetPath)
+				err = trace.ImportTraceFile(ctx, traceClient, targetPath, traceImportOpts...)
+			}
 		} else {
 			log.Printf("WARN: skip unsupported target path %q", targetPath)
+			continue
+		}
+		if nil != err {
+			log.Printf("WARN: failed to import target path %q: %v", targetPath, err)
 		}
 	}
 	log.Print("INFO: completed.")
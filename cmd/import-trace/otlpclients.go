@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// otlpClientOptions collects the endpoint/auth knobs shared by the log and
+// metric gRPC uploader adapters. The trace client reuses the same values
+// but is configured through otlptracegrpc/otlptracehttp instead, since it
+// goes through the OTLP trace exporter's own Client abstraction.
+type otlpClientOptions struct {
+	endpoint     string
+	insecureMode bool
+	headers      map[string]string
+	compression  string
+	timeout      time.Duration
+	tlsConfig    *tls.Config
+}
+
+func (o otlpClientOptions) dialOptions() []grpc.DialOption {
+	var dialOpts []grpc.DialOption
+	switch {
+	case o.insecureMode:
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	case o.tlsConfig != nil:
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(o.tlsConfig)))
+	default:
+		// Mirror otlptracegrpc/otlptracehttp's own default: when neither
+		// -insecure nor mTLS material is given, fall back to plain TLS
+		// against the system root CAs rather than requiring the caller to
+		// wire up credentials explicitly.
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+	if o.compression != "" {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(o.compression)))
+	}
+	return dialOpts
+}
+
+func (o otlpClientOptions) withRPCDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(o.headers) > 0 {
+		md := metadata.New(o.headers)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	if o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// grpcLogsClient adapts the raw OTLP collector logs service to log.Client,
+// mirroring how otlptracegrpc.Client wraps the trace collector service.
+type grpcLogsClient struct {
+	conn *grpc.ClientConn
+	svc  collogspb.LogsServiceClient
+	opts otlpClientOptions
+}
+
+func newGRPCLogsClient(opts otlpClientOptions) (client *grpcLogsClient, err error) {
+	conn, err := grpc.NewClient(opts.endpoint, opts.dialOptions()...)
+	if nil != err {
+		return
+	}
+	client = &grpcLogsClient{
+		conn: conn,
+		svc:  collogspb.NewLogsServiceClient(conn),
+		opts: opts,
+	}
+	return
+}
+
+func (c *grpcLogsClient) UploadLogs(ctx context.Context, resourceLogs []*logspb.ResourceLogs) (err error) {
+	ctx, cancel := c.opts.withRPCDeadline(ctx)
+	defer cancel()
+	_, err = c.svc.Export(ctx, &collogspb.ExportLogsServiceRequest{ResourceLogs: resourceLogs})
+	return
+}
+
+func (c *grpcLogsClient) Close() error {
+	return c.conn.Close()
+}
+
+// grpcMetricsClient adapts the raw OTLP collector metrics service to
+// metric.Client, mirroring how otlptracegrpc.Client wraps the trace
+// collector service.
+type grpcMetricsClient struct {
+	conn *grpc.ClientConn
+	svc  colmetricspb.MetricsServiceClient
+	opts otlpClientOptions
+}
+
+func newGRPCMetricsClient(opts otlpClientOptions) (client *grpcMetricsClient, err error) {
+	conn, err := grpc.NewClient(opts.endpoint, opts.dialOptions()...)
+	if nil != err {
+		return
+	}
+	client = &grpcMetricsClient{
+		conn: conn,
+		svc:  colmetricspb.NewMetricsServiceClient(conn),
+		opts: opts,
+	}
+	return
+}
+
+func (c *grpcMetricsClient) UploadMetrics(ctx context.Context, resourceMetrics []*metricspb.ResourceMetrics) (err error) {
+	ctx, cancel := c.opts.withRPCDeadline(ctx)
+	defer cancel()
+	_, err = c.svc.Export(ctx, &colmetricspb.ExportMetricsServiceRequest{ResourceMetrics: resourceMetrics})
+	return
+}
+
+func (c *grpcMetricsClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	signalTrace  = "trace"
+	signalLog    = "log"
+	signalMetric = "metric"
+)
+
+func hasSignalFile(folderPath string) bool {
+	_, err := os.Stat(filepath.Join(folderPath, "_signal"))
+	return err == nil
+}
+
+// detectSignalKindOfFolder reads the `_signal` sidecar written by the
+// corresponding FilesTraceExporter/FilesLogsExporter/FilesMetricsExporter
+// when it prepared the folder. Folders written before the `_signal` sidecar
+// existed are assumed to hold traces.
+func detectSignalKindOfFolder(folderPath string) string {
+	content, err := os.ReadFile(filepath.Join(folderPath, "_signal"))
+	if nil != err {
+		return signalTrace
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// detectSignalKindOfFile prefers the folder's `_signal` sidecar, and
+// otherwise sniffs the first framed protobuf record by trial unmarshalling
+// it as ResourceLogs and then as ResourceMetrics; the first that parses with
+// a non-empty scope list wins, and anything else is reported as a trace.
+// This is best-effort: the protobuf wire format does not carry a type tag,
+// so a record that parses as more than one kind is reported as a log.
+func detectSignalKindOfFile(filePath string) string {
+	folderPath := filepath.Dir(filePath)
+	if hasSignalFile(folderPath) {
+		return detectSignalKindOfFolder(folderPath)
+	}
+	recordBuf, err := peekFirstRecord(filePath)
+	if nil != err || recordBuf == nil {
+		return signalTrace
+	}
+	var resourceLogs logspb.ResourceLogs
+	if err := proto.Unmarshal(recordBuf, &resourceLogs); nil == err && len(resourceLogs.ScopeLogs) > 0 {
+		return signalLog
+	}
+	var resourceMetrics metricspb.ResourceMetrics
+	if err := proto.Unmarshal(recordBuf, &resourceMetrics); nil == err && len(resourceMetrics.ScopeMetrics) > 0 {
+		return signalMetric
+	}
+	var resourceSpans tracepb.ResourceSpans
+	if err := proto.Unmarshal(recordBuf, &resourceSpans); nil == err {
+		return signalTrace
+	}
+	return signalTrace
+}
+
+// peekFirstRecord reads the first framed record (count + size + payload)
+// of the log/metric spool framing without fully parsing it. Trace files
+// start with a codec-tagged chunk header instead, which is not parsed here,
+// so for them the returned bytes are not a whole record.
+func peekFirstRecord(filePath string) (recordBuf []byte, err error) {
+	fp, err := os.Open(filePath)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	var header [8]byte
+	if _, err = fp.Read(header[:]); nil != err {
+		return
+	}
+	recordSize := int(header[4]) | int(header[5])<<8 | int(header[6])<<16 | int(header[7])<<24
+	if recordSize <= 0 {
+		return
+	}
+	recordBuf = make([]byte, recordSize)
+	if _, err = fp.Read(recordBuf); nil != err {
+		recordBuf = nil
+	}
+	return
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// loadTLSConfig builds a client TLS config for mTLS against a managed
+// collector. All three parameters are optional; it returns a nil config
+// (meaning: use the transport's own default TLS/insecure handling) when
+// none are given.
+func loadTLSConfig(caFile, certFile, keyFile string) (tlsConfig *tls.Config, err error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	tlsConfig = &tls.Config{}
+	if caFile != "" {
+		caBytes, err0 := os.ReadFile(caFile)
+		if nil != err0 {
+			return nil, fmt.Errorf("cannot read CA file %q: %w", caFile, err0)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("cannot parse CA file %q", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("both -cert-file and -key-file are required for mTLS")
+		}
+		cert, err0 := tls.LoadX509KeyPair(certFile, keyFile)
+		if nil != err0 {
+			return nil, fmt.Errorf("cannot load client key pair (%q, %q): %w", certFile, keyFile, err0)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"google.golang.org/grpc/credentials"
+)
+
+// newTraceClient builds the OTLP trace client for the requested transport
+// protocol, applying the same endpoint/auth knobs the log and metric gRPC
+// uploader adapters use.
+func newTraceClient(protocol string, opts otlpClientOptions) (otlptrace.Client, error) {
+	switch protocol {
+	case "", "grpc":
+		grpcOpts := make([]otlptracegrpc.Option, 0, 6)
+		if opts.insecureMode {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		} else if opts.tlsConfig != nil {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(opts.tlsConfig)))
+		}
+		grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(opts.endpoint))
+		if len(opts.headers) > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithHeaders(opts.headers))
+		}
+		if opts.compression != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithCompressor(opts.compression))
+		}
+		if opts.timeout > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTimeout(opts.timeout))
+		}
+		return otlptracegrpc.NewClient(grpcOpts...), nil
+	case "http/protobuf":
+		httpOpts := make([]otlptracehttp.Option, 0, 6)
+		if opts.insecureMode {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		} else if opts.tlsConfig != nil {
+			httpOpts = append(httpOpts, otlptracehttp.WithTLSClientConfig(opts.tlsConfig))
+		}
+		httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(opts.endpoint))
+		if len(opts.headers) > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithHeaders(opts.headers))
+		}
+		if opts.compression == "gzip" {
+			httpOpts = append(httpOpts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if opts.timeout > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithTimeout(opts.timeout))
+		}
+		return otlptracehttp.NewClient(httpOpts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q (want \"grpc\" or \"http/protobuf\")", protocol)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/yinyin/go-otel-exporter-files/trace"
+)
+
+// runVerify implements the `verify` subcommand: re-read every output file
+// recorded in one or more spool folders' `_index` sidecars, recompute its
+// checksum and span count, and report any mismatch.
+func runVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fs.Parse(args)
+	folderPaths := fs.Args()
+	if len(folderPaths) == 0 {
+		log.Fatal("verify: required one or more spool folder paths")
+		return
+	}
+	exitCode := 0
+	for _, folderPath := range folderPaths {
+		issues, err := trace.VerifyFolder(folderPath)
+		if nil != err {
+			log.Printf("WARN: cannot verify folder %q: %v", folderPath, err)
+			exitCode = 1
+			continue
+		}
+		if len(issues) == 0 {
+			log.Printf("INFO: folder %q OK", folderPath)
+			continue
+		}
+		exitCode = 1
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", folderPath, issue)
+		}
+	}
+	os.Exit(exitCode)
+}
@@ -0,0 +1,92 @@
+package log
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client is the minimal uploader surface ImportLogFile needs to re-submit
+// decoded OTLP resource logs to a collector.
+type Client interface {
+	UploadLogs(ctx context.Context, resourceLogs []*logspb.ResourceLogs) error
+}
+
+func ImportLogFile(ctx context.Context, client Client, logFilePath string) (err error) {
+	fp, err := os.Open(logFilePath)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	for {
+		var i32buf [4]byte
+		if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
+			if err == io.EOF {
+				err = nil
+			} else {
+				err = fmt.Errorf("cannot have record count from log file %q: %w", logFilePath, err)
+			}
+			return
+		}
+		remainRecordCount := int(binary.LittleEndian.Uint32(i32buf[:]))
+		logRecords := make([]*logspb.ResourceLogs, 0, remainRecordCount)
+		for remainRecordCount > 0 {
+			if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
+				err = fmt.Errorf("cannot have record size from log file %q: %w", logFilePath, err)
+				return
+			}
+			recordSize := int(binary.LittleEndian.Uint32(i32buf[:]))
+			if recordSize < 0 {
+				err = fmt.Errorf("invalid record size from log file %q: %w", logFilePath, err)
+				return
+			} else if recordSize == 0 {
+				continue
+			}
+			remainRecordCount--
+			recordBuf := make([]byte, recordSize)
+			if _, err = io.ReadFull(fp, recordBuf); nil != err {
+				err = fmt.Errorf("cannot read record data from log file %q: %w", logFilePath, err)
+				return
+			}
+			resourceLogs := &logspb.ResourceLogs{}
+			if err = proto.Unmarshal(recordBuf, resourceLogs); nil != err {
+				err = fmt.Errorf("cannot unmarshal record data from log file %q: %w", logFilePath, err)
+				return
+			}
+			logRecords = append(logRecords, resourceLogs)
+		}
+		if recordCount := len(logRecords); recordCount > 0 {
+			if err = client.UploadLogs(ctx, logRecords); nil != err {
+				err = fmt.Errorf("cannot upload %d resource logs from log file %q: %w", recordCount, logFilePath, err)
+				return
+			}
+		}
+	}
+}
+
+func ImportLogFolder(ctx context.Context, client Client, folderPath string) (err error) {
+	entries, err := os.ReadDir(folderPath)
+	if err != nil {
+		err = fmt.Errorf("cannot read log folder %q: %w", folderPath, err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+		if (len(fileName) > 1) && ((fileName[0] == '_') || (fileName[0] == '.')) {
+			continue
+		}
+		if err = ImportLogFile(ctx, client, filepath.Join(folderPath, fileName)); nil != err {
+			return
+		}
+	}
+	return
+}
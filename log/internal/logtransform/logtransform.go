@@ -0,0 +1,152 @@
+// Package logtransform converts SDK log records into their OTLP protobuf
+// representation, grouping records by resource and instrumentation scope the
+// same way the collector expects ResourceLogs/ScopeLogs to be nested.
+package logtransform
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// Records converts a batch of SDK log records into OTLP ResourceLogs,
+// grouping by resource and then by instrumentation scope.
+func Records(records []sdklog.Record) []*logspb.ResourceLogs {
+	resourceLogsByResource := make(map[string]*logspb.ResourceLogs)
+	order := make([]string, 0, len(records))
+	scopeLogsByKey := make(map[string]*logspb.ScopeLogs)
+
+	for _, record := range records {
+		res := record.Resource()
+		resourceKey := res.String()
+		resourceLogs, ok := resourceLogsByResource[resourceKey]
+		if !ok {
+			resourceLogs = &logspb.ResourceLogs{
+				Resource:  resourcePB(res),
+				SchemaUrl: res.SchemaURL(),
+			}
+			resourceLogsByResource[resourceKey] = resourceLogs
+			order = append(order, resourceKey)
+		}
+
+		scope := record.InstrumentationScope()
+		scopeKey := resourceKey + "|" + scope.Name + "|" + scope.Version + "|" + scope.SchemaURL
+		scopeLogs, ok := scopeLogsByKey[scopeKey]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    scope.Name,
+					Version: scope.Version,
+				},
+				SchemaUrl: scope.SchemaURL,
+			}
+			scopeLogsByKey[scopeKey] = scopeLogs
+			resourceLogs.ScopeLogs = append(resourceLogs.ScopeLogs, scopeLogs)
+		}
+
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, logRecord(record))
+	}
+
+	resourceLogs := make([]*logspb.ResourceLogs, 0, len(order))
+	for _, key := range order {
+		resourceLogs = append(resourceLogs, resourceLogsByResource[key])
+	}
+	return resourceLogs
+}
+
+func resourcePB(res *resource.Resource) *resourcepb.Resource {
+	iter := res.Iter()
+	attrs := make([]*commonpb.KeyValue, 0, iter.Len())
+	for iter.Next() {
+		attrs = append(attrs, attributeKeyValue(iter.Attribute()))
+	}
+	return &resourcepb.Resource{Attributes: attrs}
+}
+
+func logRecord(record sdklog.Record) *logspb.LogRecord {
+	pbRecord := &logspb.LogRecord{
+		TimeUnixNano:           uint64(record.Timestamp().UnixNano()),
+		ObservedTimeUnixNano:   uint64(record.ObservedTimestamp().UnixNano()),
+		SeverityNumber:         logspb.SeverityNumber(record.Severity()),
+		SeverityText:           record.SeverityText(),
+		Body:                   anyValue(record.Body()),
+		DroppedAttributesCount: uint32(record.DroppedAttributes()),
+		Flags:                  uint32(record.TraceFlags()),
+	}
+	if traceID := record.TraceID(); traceID.IsValid() {
+		pbRecord.TraceId = traceID[:]
+	}
+	if spanID := record.SpanID(); spanID.IsValid() {
+		pbRecord.SpanId = spanID[:]
+	}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		pbRecord.Attributes = append(pbRecord.Attributes, logKeyValue(kv))
+		return true
+	})
+	return pbRecord
+}
+
+func attributeKeyValue(kv attribute.KeyValue) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   string(kv.Key),
+		Value: attributeValue(kv.Value),
+	}
+}
+
+func attributeValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.STRING:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
+
+func logKeyValue(kv log.KeyValue) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   string(kv.Key),
+		Value: anyValue(kv.Value),
+	}
+}
+
+func anyValue(v log.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case log.KindBool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case log.KindFloat64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case log.KindInt64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case log.KindString:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case log.KindBytes:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.AsBytes()}}
+	case log.KindSlice:
+		elems := v.AsSlice()
+		values := make([]*commonpb.AnyValue, len(elems))
+		for i, elem := range elems {
+			values[i] = anyValue(elem)
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case log.KindMap:
+		entries := v.AsMap()
+		values := make([]*commonpb.KeyValue, len(entries))
+		for i, entry := range entries {
+			values[i] = logKeyValue(entry)
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: values}}}
+	default:
+		return &commonpb.AnyValue{}
+	}
+}
@@ -0,0 +1,94 @@
+package logtransform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// captureExporter collects every record handed to it by the SDK so the test
+// can feed real sdklog.Record values - which have no public constructor -
+// into Records without reaching into SDK internals.
+type captureExporter struct {
+	records []sdklog.Record
+}
+
+func (c *captureExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	c.records = append(c.records, records...)
+	return nil
+}
+
+func (c *captureExporter) Shutdown(ctx context.Context) error   { return nil }
+func (c *captureExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func TestRecords(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "test-service"))
+	capture := &captureExporter{}
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(capture)),
+	)
+	defer lp.Shutdown(context.Background())
+
+	logger := lp.Logger("test-scope", otellog.WithInstrumentationVersion("v1"))
+
+	var rec otellog.Record
+	rec.SetTimestamp(time.Unix(1700000000, 0))
+	rec.SetSeverity(otellog.SeverityInfo)
+	rec.SetBody(otellog.StringValue("hello"))
+	rec.AddAttributes(otellog.String("k", "v"))
+	logger.Emit(context.Background(), rec)
+
+	if len(capture.records) != 1 {
+		t.Fatalf("expected 1 captured record, got %d", len(capture.records))
+	}
+
+	resourceLogs := Records(capture.records)
+	if len(resourceLogs) != 1 {
+		t.Fatalf("expected 1 ResourceLogs, got %d", len(resourceLogs))
+	}
+	rl := resourceLogs[0]
+	if len(rl.ScopeLogs) != 1 {
+		t.Fatalf("expected 1 ScopeLogs, got %d", len(rl.ScopeLogs))
+	}
+	sl := rl.ScopeLogs[0]
+	if sl.Scope.Name != "test-scope" {
+		t.Errorf("scope name = %q, want %q", sl.Scope.Name, "test-scope")
+	}
+	if len(sl.LogRecords) != 1 {
+		t.Fatalf("expected 1 LogRecord, got %d", len(sl.LogRecords))
+	}
+	lr := sl.LogRecords[0]
+	if got := lr.Body.GetStringValue(); got != "hello" {
+		t.Errorf("body = %q, want %q", got, "hello")
+	}
+	if (len(lr.Attributes) != 1) || (lr.Attributes[0].Key != "k") {
+		t.Fatalf("unexpected attributes: %+v", lr.Attributes)
+	}
+}
+
+func TestRecordsGroupsByResourceAndScope(t *testing.T) {
+	capture := &captureExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(capture)))
+	defer lp.Shutdown(context.Background())
+
+	for _, scopeName := range []string{"scope-a", "scope-b"} {
+		logger := lp.Logger(scopeName)
+		var rec otellog.Record
+		rec.SetBody(otellog.StringValue(scopeName))
+		logger.Emit(context.Background(), rec)
+	}
+
+	resourceLogs := Records(capture.records)
+	if len(resourceLogs) != 1 {
+		t.Fatalf("expected 1 ResourceLogs (shared resource), got %d", len(resourceLogs))
+	}
+	if got := len(resourceLogs[0].ScopeLogs); got != 2 {
+		t.Fatalf("expected 2 ScopeLogs (one per instrumentation scope), got %d", got)
+	}
+}
@@ -0,0 +1,92 @@
+package metric
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client is the minimal uploader surface ImportMetricFile needs to re-submit
+// decoded OTLP resource metrics to a collector.
+type Client interface {
+	UploadMetrics(ctx context.Context, resourceMetrics []*metricspb.ResourceMetrics) error
+}
+
+func ImportMetricFile(ctx context.Context, client Client, metricFilePath string) (err error) {
+	fp, err := os.Open(metricFilePath)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	for {
+		var i32buf [4]byte
+		if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
+			if err == io.EOF {
+				err = nil
+			} else {
+				err = fmt.Errorf("cannot have record count from metric file %q: %w", metricFilePath, err)
+			}
+			return
+		}
+		remainRecordCount := int(binary.LittleEndian.Uint32(i32buf[:]))
+		resourceMetricsS := make([]*metricspb.ResourceMetrics, 0, remainRecordCount)
+		for remainRecordCount > 0 {
+			if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
+				err = fmt.Errorf("cannot have record size from metric file %q: %w", metricFilePath, err)
+				return
+			}
+			recordSize := int(binary.LittleEndian.Uint32(i32buf[:]))
+			if recordSize < 0 {
+				err = fmt.Errorf("invalid record size from metric file %q: %w", metricFilePath, err)
+				return
+			} else if recordSize == 0 {
+				continue
+			}
+			remainRecordCount--
+			recordBuf := make([]byte, recordSize)
+			if _, err = io.ReadFull(fp, recordBuf); nil != err {
+				err = fmt.Errorf("cannot read record data from metric file %q: %w", metricFilePath, err)
+				return
+			}
+			resourceMetrics := &metricspb.ResourceMetrics{}
+			if err = proto.Unmarshal(recordBuf, resourceMetrics); nil != err {
+				err = fmt.Errorf("cannot unmarshal record data from metric file %q: %w", metricFilePath, err)
+				return
+			}
+			resourceMetricsS = append(resourceMetricsS, resourceMetrics)
+		}
+		if recordCount := len(resourceMetricsS); recordCount > 0 {
+			if err = client.UploadMetrics(ctx, resourceMetricsS); nil != err {
+				err = fmt.Errorf("cannot upload %d resource metrics from metric file %q: %w", recordCount, metricFilePath, err)
+				return
+			}
+		}
+	}
+}
+
+func ImportMetricFolder(ctx context.Context, client Client, folderPath string) (err error) {
+	entries, err := os.ReadDir(folderPath)
+	if err != nil {
+		err = fmt.Errorf("cannot read metric folder %q: %w", folderPath, err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+		if (len(fileName) > 1) && ((fileName[0] == '_') || (fileName[0] == '.')) {
+			continue
+		}
+		if err = ImportMetricFile(ctx, client, filepath.Join(folderPath, fileName)); nil != err {
+			return
+		}
+	}
+	return
+}
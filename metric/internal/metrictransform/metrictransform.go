@@ -0,0 +1,184 @@
+// Package metrictransform converts SDK-aggregated metric data into its OTLP
+// protobuf representation.
+package metrictransform
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// ResourceMetrics converts one SDK ResourceMetrics snapshot into its OTLP
+// protobuf representation.
+func ResourceMetrics(rm *metricdata.ResourceMetrics) (*metricspb.ResourceMetrics, error) {
+	pbResourceMetrics := &metricspb.ResourceMetrics{
+		Resource: &resourcepb.Resource{
+			Attributes: attributeKeyValues(rm.Resource),
+		},
+		SchemaUrl: rm.Resource.SchemaURL(),
+	}
+	var errS []error
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		pbScopeMetrics := &metricspb.ScopeMetrics{
+			Scope: &commonpb.InstrumentationScope{
+				Name:    scopeMetrics.Scope.Name,
+				Version: scopeMetrics.Scope.Version,
+			},
+			SchemaUrl: scopeMetrics.Scope.SchemaURL,
+		}
+		for _, m := range scopeMetrics.Metrics {
+			pbMetric, err := metric(m)
+			if nil != err {
+				errS = append(errS, fmt.Errorf("cannot transform metric %q: %w", m.Name, err))
+				continue
+			}
+			pbScopeMetrics.Metrics = append(pbScopeMetrics.Metrics, pbMetric)
+		}
+		pbResourceMetrics.ScopeMetrics = append(pbResourceMetrics.ScopeMetrics, pbScopeMetrics)
+	}
+	if len(errS) > 0 {
+		return pbResourceMetrics, fmt.Errorf("cannot transform %d metrics", len(errS))
+	}
+	return pbResourceMetrics, nil
+}
+
+func metric(m metricdata.Metrics) (*metricspb.Metric, error) {
+	pbMetric := &metricspb.Metric{
+		Name:        m.Name,
+		Description: m.Description,
+		Unit:        m.Unit,
+	}
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		pbMetric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: numberDataPoints(data.DataPoints)}}
+	case metricdata.Gauge[float64]:
+		pbMetric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: numberDataPoints(data.DataPoints)}}
+	case metricdata.Sum[int64]:
+		pbMetric.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             numberDataPoints(data.DataPoints),
+			AggregationTemporality: temporality(data.Temporality),
+			IsMonotonic:            data.IsMonotonic,
+		}}
+	case metricdata.Sum[float64]:
+		pbMetric.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             numberDataPoints(data.DataPoints),
+			AggregationTemporality: temporality(data.Temporality),
+			IsMonotonic:            data.IsMonotonic,
+		}}
+	case metricdata.Histogram[int64]:
+		pbMetric.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             histogramDataPoints(data.DataPoints),
+			AggregationTemporality: temporality(data.Temporality),
+		}}
+	case metricdata.Histogram[float64]:
+		pbMetric.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             histogramDataPoints(data.DataPoints),
+			AggregationTemporality: temporality(data.Temporality),
+		}}
+	default:
+		return nil, fmt.Errorf("unsupported metric data type %T", m.Data)
+	}
+	return pbMetric, nil
+}
+
+func temporality(t metricdata.Temporality) metricspb.AggregationTemporality {
+	switch t {
+	case metricdata.DeltaTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	case metricdata.CumulativeTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	default:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+	}
+}
+
+func numberDataPoints[N int64 | float64](dataPoints []metricdata.DataPoint[N]) []*metricspb.NumberDataPoint {
+	pbDataPoints := make([]*metricspb.NumberDataPoint, len(dataPoints))
+	for i, dp := range dataPoints {
+		pbDataPoint := &metricspb.NumberDataPoint{
+			Attributes:        attributeSetKeyValues(dp.Attributes),
+			StartTimeUnixNano: uint64(dp.StartTime.UnixNano()),
+			TimeUnixNano:      uint64(dp.Time.UnixNano()),
+		}
+		switch v := any(dp.Value).(type) {
+		case int64:
+			pbDataPoint.Value = &metricspb.NumberDataPoint_AsInt{AsInt: v}
+		case float64:
+			pbDataPoint.Value = &metricspb.NumberDataPoint_AsDouble{AsDouble: v}
+		}
+		pbDataPoints[i] = pbDataPoint
+	}
+	return pbDataPoints
+}
+
+func histogramDataPoints[N int64 | float64](dataPoints []metricdata.HistogramDataPoint[N]) []*metricspb.HistogramDataPoint {
+	pbDataPoints := make([]*metricspb.HistogramDataPoint, len(dataPoints))
+	for i, dp := range dataPoints {
+		sum := float64(dp.Sum)
+		pbDataPoint := &metricspb.HistogramDataPoint{
+			Attributes:        attributeSetKeyValues(dp.Attributes),
+			StartTimeUnixNano: uint64(dp.StartTime.UnixNano()),
+			TimeUnixNano:      uint64(dp.Time.UnixNano()),
+			Count:             dp.Count,
+			Sum:               &sum,
+			BucketCounts:      dp.BucketCounts,
+			ExplicitBounds:    dp.Bounds,
+		}
+		if v, ok := dp.Min.Value(); ok {
+			min := float64(v)
+			pbDataPoint.Min = &min
+		}
+		if v, ok := dp.Max.Value(); ok {
+			max := float64(v)
+			pbDataPoint.Max = &max
+		}
+		pbDataPoints[i] = pbDataPoint
+	}
+	return pbDataPoints
+}
+
+func attributeSetKeyValues(set attribute.Set) []*commonpb.KeyValue {
+	iter := set.Iter()
+	attrs := make([]*commonpb.KeyValue, 0, iter.Len())
+	for iter.Next() {
+		attrs = append(attrs, attributeKeyValue(iter.Attribute()))
+	}
+	return attrs
+}
+
+func attributeKeyValues(res *resource.Resource) []*commonpb.KeyValue {
+	iter := res.Iter()
+	attrs := make([]*commonpb.KeyValue, 0, iter.Len())
+	for iter.Next() {
+		attrs = append(attrs, attributeKeyValue(iter.Attribute()))
+	}
+	return attrs
+}
+
+func attributeKeyValue(kv attribute.KeyValue) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   string(kv.Key),
+		Value: attributeValue(kv.Value),
+	}
+}
+
+func attributeValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.STRING:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
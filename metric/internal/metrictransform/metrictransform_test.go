@@ -0,0 +1,77 @@
+package metrictransform
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestResourceMetricsGauge(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+	rm := &metricdata.ResourceMetrics{
+		Resource: res,
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Metrics: []metricdata.Metrics{
+					{
+						Name: "requests",
+						Data: metricdata.Gauge[int64]{
+							DataPoints: []metricdata.DataPoint[int64]{
+								{Value: 42},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	pb, err := ResourceMetrics(rm)
+	if nil != err {
+		t.Fatalf("ResourceMetrics() error = %v", err)
+	}
+	if (len(pb.ScopeMetrics) != 1) || (len(pb.ScopeMetrics[0].Metrics) != 1) {
+		t.Fatalf("unexpected shape: %+v", pb)
+	}
+	gauge := pb.ScopeMetrics[0].Metrics[0].GetGauge()
+	if (nil == gauge) || (len(gauge.DataPoints) != 1) {
+		t.Fatalf("expected 1 gauge data point, got %+v", gauge)
+	}
+	if got := gauge.DataPoints[0].GetAsInt(); got != 42 {
+		t.Errorf("gauge value = %d, want 42", got)
+	}
+}
+
+// TestHistogramDataPointsMinMax exercises the dp.Min/dp.Max conversion via
+// metricdata.Extrema's Value() method; a prior version read nonexistent
+// Valid/Value fields and failed to build.
+func TestHistogramDataPointsMinMax(t *testing.T) {
+	dp := metricdata.HistogramDataPoint[float64]{
+		Count:        3,
+		Sum:          6,
+		BucketCounts: []uint64{1, 2},
+		Bounds:       []float64{1},
+		Min:          metricdata.NewExtrema(1.0),
+		Max:          metricdata.NewExtrema(5.0),
+	}
+	pbDataPoints := histogramDataPoints([]metricdata.HistogramDataPoint[float64]{dp})
+	if len(pbDataPoints) != 1 {
+		t.Fatalf("expected 1 data point, got %d", len(pbDataPoints))
+	}
+	pbDp := pbDataPoints[0]
+	if (nil == pbDp.Min) || (*pbDp.Min != 1.0) {
+		t.Errorf("min = %v, want 1.0", pbDp.Min)
+	}
+	if (nil == pbDp.Max) || (*pbDp.Max != 5.0) {
+		t.Errorf("max = %v, want 5.0", pbDp.Max)
+	}
+}
+
+func TestHistogramDataPointsNoMinMax(t *testing.T) {
+	dp := metricdata.HistogramDataPoint[float64]{Count: 1, Sum: 1}
+	pbDataPoints := histogramDataPoints([]metricdata.HistogramDataPoint[float64]{dp})
+	if (nil != pbDataPoints[0].Min) || (nil != pbDataPoints[0].Max) {
+		t.Errorf("expected no min/max for a zero-value Extrema, got min=%v max=%v", pbDataPoints[0].Min, pbDataPoints[0].Max)
+	}
+}
@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// checkpointPath derives the sidecar path recording the byte offset of the
+// last successfully uploaded chunk for traceFilePath. The trace file's
+// absolute path is folded into the name so files sharing a base name across
+// different hour-bucketed folders do not collide.
+func checkpointPath(checkpointDir, traceFilePath string) string {
+	abs, err := filepath.Abs(traceFilePath)
+	if nil != err {
+		abs = traceFilePath
+	}
+	key := strings.TrimPrefix(filepath.ToSlash(abs), "/")
+	key = strings.ReplaceAll(key, "/", "_")
+	return filepath.Join(checkpointDir, key+".ckpt")
+}
+
+func readCheckpointOffset(checkpointDir, traceFilePath string) (offset int64, err error) {
+	if checkpointDir == "" {
+		return
+	}
+	content, err0 := os.ReadFile(checkpointPath(checkpointDir, traceFilePath))
+	if nil != err0 {
+		if os.IsNotExist(err0) {
+			return 0, nil
+		}
+		err = fmt.Errorf("cannot read checkpoint for %q: %w", traceFilePath, err0)
+		return
+	}
+	if offset, err = strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64); nil != err {
+		err = fmt.Errorf("cannot parse checkpoint for %q: %w", traceFilePath, err)
+	}
+	return
+}
+
+func writeCheckpointOffset(checkpointDir, traceFilePath string, offset int64) (err error) {
+	if checkpointDir == "" {
+		return
+	}
+	if err = os.MkdirAll(checkpointDir, 0o755); nil != err {
+		err = fmt.Errorf("cannot create checkpoint dir %q: %w", checkpointDir, err)
+		return
+	}
+	p := checkpointPath(checkpointDir, traceFilePath)
+	tmp := p + ".tmp"
+	if err = os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)), 0o644); nil != err {
+		err = fmt.Errorf("cannot write checkpoint %q: %w", tmp, err)
+		return
+	}
+	if err = os.Rename(tmp, p); nil != err {
+		err = fmt.Errorf("cannot rename checkpoint %q to %q: %w", tmp, p, err)
+	}
+	return
+}
+
+func removeCheckpoint(checkpointDir, traceFilePath string) {
+	if checkpointDir == "" {
+		return
+	}
+	os.Remove(checkpointPath(checkpointDir, traceFilePath))
+}
@@ -0,0 +1,50 @@
+package trace
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	traceFilePath := filepath.Join(t.TempDir(), "0g")
+
+	if offset, err := readCheckpointOffset(dir, traceFilePath); (nil != err) || (offset != 0) {
+		t.Fatalf("readCheckpointOffset() = (%d, %v), want (0, nil) when no checkpoint exists", offset, err)
+	}
+
+	if err := writeCheckpointOffset(dir, traceFilePath, 1234); nil != err {
+		t.Fatalf("writeCheckpointOffset() error = %v", err)
+	}
+	if offset, err := readCheckpointOffset(dir, traceFilePath); (nil != err) || (offset != 1234) {
+		t.Fatalf("readCheckpointOffset() = (%d, %v), want (1234, nil)", offset, err)
+	}
+
+	// a second write should replace, not append to, the sidecar.
+	if err := writeCheckpointOffset(dir, traceFilePath, 5678); nil != err {
+		t.Fatalf("writeCheckpointOffset() error = %v", err)
+	}
+	if offset, err := readCheckpointOffset(dir, traceFilePath); (nil != err) || (offset != 5678) {
+		t.Fatalf("readCheckpointOffset() after resume = (%d, %v), want (5678, nil)", offset, err)
+	}
+
+	removeCheckpoint(dir, traceFilePath)
+	if offset, err := readCheckpointOffset(dir, traceFilePath); (nil != err) || (offset != 0) {
+		t.Fatalf("readCheckpointOffset() after remove = (%d, %v), want (0, nil)", offset, err)
+	}
+}
+
+func TestCheckpointPathNoCollisionAcrossFolders(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "hourA", "0g")
+	b := filepath.Join(dir, "hourB", "0g")
+	if checkpointPath(dir, a) == checkpointPath(dir, b) {
+		t.Fatalf("checkpoint paths for same-named files in different hour folders collided: %q", checkpointPath(dir, a))
+	}
+}
+
+func TestReadCheckpointOffsetDisabled(t *testing.T) {
+	if offset, err := readCheckpointOffset("", "/some/trace/file"); (nil != err) || (offset != 0) {
+		t.Fatalf("readCheckpointOffset() with empty checkpointDir = (%d, %v), want (0, nil)", offset, err)
+	}
+}
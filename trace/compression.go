@@ -0,0 +1,94 @@
+package trace
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec identifies the compression algorithm applied to a chunk (the
+// per-batch buffer produced by marshalSpans) before it is appended to the
+// output file. Each chunk is prefixed with its own codec tag, so a single
+// file may mix chunks compressed with different codecs.
+type Codec byte
+
+const (
+	CodecNone Codec = iota
+	CodecGzip
+	CodecSnappy
+	CodecZstd
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecGzip:
+		return "gzip"
+	case CodecSnappy:
+		return "snappy"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("codec(%d)", byte(c))
+	}
+}
+
+func compressChunk(codec Codec, buf []byte) (out []byte, err error) {
+	switch codec {
+	case CodecNone:
+		out = buf
+	case CodecGzip:
+		var b bytes.Buffer
+		gw := gzip.NewWriter(&b)
+		if _, err = gw.Write(buf); nil != err {
+			return
+		}
+		if err = gw.Close(); nil != err {
+			return
+		}
+		out = b.Bytes()
+	case CodecSnappy:
+		out = snappy.Encode(nil, buf)
+	case CodecZstd:
+		var enc *zstd.Encoder
+		if enc, err = zstd.NewWriter(nil); nil != err {
+			return
+		}
+		out = enc.EncodeAll(buf, nil)
+		err = enc.Close()
+	default:
+		err = fmt.Errorf("unsupported compression codec %s", codec)
+	}
+	return
+}
+
+func decompressChunk(codec Codec, buf []byte) (out []byte, err error) {
+	switch codec {
+	case CodecNone:
+		out = buf
+	case CodecGzip:
+		var gr *gzip.Reader
+		if gr, err = gzip.NewReader(bytes.NewReader(buf)); nil != err {
+			return
+		}
+		defer gr.Close()
+		out, err = io.ReadAll(gr)
+	case CodecSnappy:
+		out, err = snappy.Decode(nil, buf)
+	case CodecZstd:
+		var dec *zstd.Decoder
+		if dec, err = zstd.NewReader(nil); nil != err {
+			return
+		}
+		defer dec.Close()
+		out, err = dec.DecodeAll(buf, nil)
+	default:
+		err = fmt.Errorf("unsupported compression codec %s", codec)
+	}
+	return
+}
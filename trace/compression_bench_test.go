@@ -0,0 +1,24 @@
+package trace
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func BenchmarkCompressChunk(b *testing.B) {
+	buf := make([]byte, 64*1024)
+	if _, err := rand.Read(buf); nil != err {
+		b.Fatal(err)
+	}
+	for _, codec := range []Codec{CodecNone, CodecGzip, CodecSnappy, CodecZstd} {
+		b.Run(codec.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(buf)))
+			for i := 0; i < b.N; i++ {
+				if _, err := compressChunk(codec, buf); nil != err {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
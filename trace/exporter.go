@@ -7,6 +7,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/crc32"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -31,16 +33,24 @@ const purgeRangeCount = 2
 
 const timestampFormat = time.RFC3339
 
+const signalKind = "trace"
+
 var b32Enc = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
 
+// crc32Table is the Castagnoli (CRC32C) table used to digest output files
+// for the `_index` sidecar; the same table is used by VerifyFolder to
+// re-check them.
+var crc32Table = crc32.MakeTable(crc32.Castagnoli)
+
 const outputHourMask = 0xFFFFFF
 const outputSnBoundary = 0x7FFFFFFD
 
 type Config struct {
-	baseFolderPath string
-	retainHours    int32
-	fileSizeLimit  int
-	marshalOpts    proto.MarshalOptions
+	baseFolderPath   string
+	retainHours      int32
+	fileSizeLimit    int
+	marshalOpts      proto.MarshalOptions
+	compressionCodec Codec
 }
 
 type FilesTraceExporter struct {
@@ -56,6 +66,8 @@ type FilesTraceExporter struct {
 	outputStartAt     time.Time
 	outputLastWriteAt time.Time
 	currentSize       int
+	outputSpanCount   int64
+	outputHash        hash.Hash32
 }
 
 func NewFilesTraceExporter(options ...Option) (exporter *FilesTraceExporter, err error) {
@@ -89,17 +101,36 @@ func (x *FilesTraceExporter) writeTimestampFile() (err error) {
 	return
 }
 
-func (x *FilesTraceExporter) appendIndexRecord() (err error) {
-	// p := filepath.Join(x.outputFolderPath, "_index")
-	content := x.outputFileName + "\t" + x.outputStartAt.Format(timestampFormat) + " - " + x.outputLastWriteAt.Format(timestampFormat) + "\n"
-	fp, err := os.OpenFile(x.indexFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if nil != err {
-		err = fmt.Errorf("cannot open index file %q: %w", x.indexFilePath, err)
+func (x *FilesTraceExporter) writeSignalFile() (err error) {
+	p := filepath.Join(x.outputFolderPath, "_signal")
+	if err = os.WriteFile(p, []byte(signalKind+"\n"), 0644); nil != err {
+		err = fmt.Errorf("cannot write signal file %q: %w", p, err)
+	}
+	return
+}
+
+// appendIndexRecord appends one record to the folder's `_index` sidecar,
+// describing the just-closed output file: its time range, span count, byte
+// size, and CRC32C digest. The record is added by writing the whole sidecar
+// to a temporary file and renaming it into place, so a reader never observes
+// a partially-written `_index`.
+func (x *FilesTraceExporter) appendIndexRecord(spanCount int64, byteSize int, checksum uint32) (err error) {
+	content := fmt.Sprintf("%s\t%s - %s\tspans=%d\tsize=%d\tcrc32c=%08x\n",
+		x.outputFileName,
+		x.outputStartAt.Format(timestampFormat), x.outputLastWriteAt.Format(timestampFormat),
+		spanCount, byteSize, checksum)
+	existing, err := os.ReadFile(x.indexFilePath)
+	if (nil != err) && !os.IsNotExist(err) {
+		err = fmt.Errorf("cannot read index file %q: %w", x.indexFilePath, err)
 		return
 	}
-	defer fp.Close()
-	if _, err = fp.WriteString(content); nil != err {
-		err = fmt.Errorf("cannot append content to index file %q: %w", x.indexFilePath, err)
+	tmp := x.indexFilePath + ".tmp"
+	if err = os.WriteFile(tmp, append(existing, []byte(content)...), 0644); nil != err {
+		err = fmt.Errorf("cannot write index file %q: %w", tmp, err)
+		return
+	}
+	if err = os.Rename(tmp, x.indexFilePath); nil != err {
+		err = fmt.Errorf("cannot rename index file %q to %q: %w", tmp, x.indexFilePath, err)
 	}
 	return
 }
@@ -121,8 +152,14 @@ func (x *FilesTraceExporter) closeOutputFile() (err error) {
 		errS = append(errS, err0)
 	}
 	x.outputFp = nil
+	spanCount, byteSize, checksum := x.outputSpanCount, x.currentSize, uint32(0)
+	if nil != x.outputHash {
+		checksum = x.outputHash.Sum32()
+	}
 	x.currentSize = 0
-	if err1 := x.appendIndexRecord(); nil != err1 {
+	x.outputSpanCount = 0
+	x.outputHash = nil
+	if err1 := x.appendIndexRecord(spanCount, byteSize, checksum); nil != err1 {
 		errS = append(errS, err1)
 	}
 	x.outputSn = x.outputSn + 1
@@ -192,6 +229,9 @@ func (x *FilesTraceExporter) prepareOutputFolder(outputHour int32) (err error) {
 	x.indexFilePath = filepath.Join(p, "_index")
 	x.outputHour = outputHour
 	x.outputSn = 0
+	if err = x.writeSignalFile(); nil != err {
+		return
+	}
 	return
 }
 
@@ -243,6 +283,7 @@ func (x *FilesTraceExporter) prepareOutputFp(recordSize int) (err error) {
 	x.outputFp = fp
 	x.outputStartAt = time.Now().UTC()
 	x.outputLastWriteAt = x.outputStartAt
+	x.outputHash = crc32.New(crc32Table)
 	// x.currentSize = 0	// already set to zero in closeOutputFile
 	return
 }
@@ -265,6 +306,24 @@ func (x *FilesTraceExporter) marshalSpans(spans []sdktrace.ReadOnlySpan) (buf []
 		pbSize := len(buf) - pbSizeOffset - 4
 		binary.LittleEndian.PutUint32(buf[pbSizeOffset:], uint32(pbSize))
 	}
+	if buf, err = x.frameChunk(buf); nil != err {
+		err = fmt.Errorf("cannot frame chunk: %w", err)
+	}
+	return
+}
+
+// frameChunk compresses a marshalled chunk with the configured codec and
+// prefixes it with a 1-byte codec tag plus the compressed length, so a
+// reader can decompress the chunk without being told which codec was used.
+func (x *FilesTraceExporter) frameChunk(chunkBuf []byte) (framed []byte, err error) {
+	compressed, err := compressChunk(x.cfg.compressionCodec, chunkBuf)
+	if nil != err {
+		return
+	}
+	framed = make([]byte, 5, 5+len(compressed))
+	framed[0] = byte(x.cfg.compressionCodec)
+	binary.LittleEndian.PutUint32(framed[1:5], uint32(len(compressed)))
+	framed = append(framed, compressed...)
 	return
 }
 
@@ -291,6 +350,8 @@ func (x *FilesTraceExporter) ExportSpans(
 	if _, err = x.outputFp.Write(buf); nil != err {
 		err = fmt.Errorf("cannot write %d spans to output file %q: %w", spanCount, x.outputFileName, err)
 	}
+	x.outputHash.Write(buf)
+	x.outputSpanCount = x.outputSpanCount + int64(len(spans))
 	x.outputLastWriteAt = time.Now().UTC()
 	x.currentSize = x.currentSize + len(buf)
 	return
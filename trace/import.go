@@ -1,8 +1,10 @@
 package trace
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,63 +15,127 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-func ImportTraceFile(ctx context.Context, client otlptrace.Client, traceFilePath string) (err error) {
+func ImportTraceFile(ctx context.Context, client otlptrace.Client, traceFilePath string, opts ...ImportOption) (err error) {
+	cfg := importConfig{}
+	for _, opt := range opts {
+		cfg = opt.applyImportOption(cfg)
+	}
+
 	fp, err := os.Open(traceFilePath)
 	if nil != err {
 		return
 	}
 	defer fp.Close()
+
+	startOffset, err := readCheckpointOffset(cfg.checkpointDir, traceFilePath)
+	if nil != err {
+		return
+	}
+	if startOffset > 0 {
+		if _, err = fp.Seek(startOffset, io.SeekStart); nil != err {
+			err = fmt.Errorf("cannot seek trace file %q to checkpoint offset %d: %w", traceFilePath, startOffset, err)
+			return
+		}
+	}
+
 	for {
-		var i32buf [4]byte
-		if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
+		if err = ctx.Err(); nil != err {
+			return
+		}
+		var chunkHeader [5]byte
+		if _, err = io.ReadFull(fp, chunkHeader[:]); nil != err {
 			if err == io.EOF {
 				err = nil
+				removeCheckpoint(cfg.checkpointDir, traceFilePath)
 			} else {
-				err = fmt.Errorf("cannot have span count from trace file %q: %w", traceFilePath, err)
+				err = fmt.Errorf("cannot have chunk header from trace file %q: %w", traceFilePath, err)
 			}
 			return
 		}
-		remainSpanCount := int(binary.LittleEndian.Uint32(i32buf[:]))
-		traceRecords := make([]*tracepb.ResourceSpans, 0, remainSpanCount)
-		for remainSpanCount > 0 {
-			if _, err = io.ReadFull(fp, i32buf[:]); nil != err {
-				err = fmt.Errorf("cannot have span size from trace file %q: %w", traceFilePath, err)
-				return
-			}
-			spanSize := int(binary.LittleEndian.Uint32(i32buf[:]))
-			if spanSize < 0 {
-				err = fmt.Errorf("invalid span size from trace file %q: %w", traceFilePath, err)
-				return
-			} else if spanSize == 0 {
-				continue
-			}
-			remainSpanCount--
-			spanBuf := make([]byte, spanSize)
-			if _, err = io.ReadFull(fp, spanBuf); nil != err {
-				err = fmt.Errorf("cannot read span data from trace file %q: %w", traceFilePath, err)
-				return
-			}
-			traceSpan := &tracepb.ResourceSpans{}
-			if err = proto.Unmarshal(spanBuf, traceSpan); nil != err {
-				err = fmt.Errorf("cannot unmarshal span data from trace file %q: %w", traceFilePath, err)
-				return
-			}
-			traceRecords = append(traceRecords, traceSpan)
+		codec := Codec(chunkHeader[0])
+		compressedLen := binary.LittleEndian.Uint32(chunkHeader[1:5])
+		compressedBuf := make([]byte, compressedLen)
+		if _, err = io.ReadFull(fp, compressedBuf); nil != err {
+			err = fmt.Errorf("cannot read compressed chunk from trace file %q: %w", traceFilePath, err)
+			return
+		}
+		chunkBuf, err0 := decompressChunk(codec, compressedBuf)
+		if nil != err0 {
+			err = fmt.Errorf("cannot decompress chunk (codec=%s) from trace file %q: %w", codec, traceFilePath, err0)
+			return
+		}
+		traceRecords, err1 := decodeChunkSpans(chunkBuf, traceFilePath)
+		if nil != err1 {
+			err = err1
+			return
 		}
 		if traceRecordCount := len(traceRecords); traceRecordCount > 0 {
-			if err = client.UploadTraces(ctx, traceRecords); nil != err {
+			if err = uploadWithRetry(ctx, client, traceRecords, cfg.retryPolicy); nil != err {
 				err = fmt.Errorf("cannot upload %d spans from trace file %q: %w", traceRecordCount, traceFilePath, err)
+				return
 			}
 		}
+		var offset int64
+		if offset, err = fp.Seek(0, io.SeekCurrent); nil != err {
+			err = fmt.Errorf("cannot have current offset of trace file %q: %w", traceFilePath, err)
+			return
+		}
+		if err = writeCheckpointOffset(cfg.checkpointDir, traceFilePath, offset); nil != err {
+			return
+		}
 	}
 }
 
-func ImportTraceFolder(ctx context.Context, client otlptrace.Client, folderPath string) (err error) {
+// decodeChunkSpans parses a decompressed chunk's span-count/size framing,
+// as produced by marshalSpans before compression.
+func decodeChunkSpans(chunkBuf []byte, traceFilePath string) (traceRecords []*tracepb.ResourceSpans, err error) {
+	r := bytes.NewReader(chunkBuf)
+	var i32buf [4]byte
+	if _, err = io.ReadFull(r, i32buf[:]); nil != err {
+		err = fmt.Errorf("cannot have span count from trace file %q: %w", traceFilePath, err)
+		return
+	}
+	remainSpanCount := int(binary.LittleEndian.Uint32(i32buf[:]))
+	traceRecords = make([]*tracepb.ResourceSpans, 0, remainSpanCount)
+	for remainSpanCount > 0 {
+		if _, err = io.ReadFull(r, i32buf[:]); nil != err {
+			err = fmt.Errorf("cannot have span size from trace file %q: %w", traceFilePath, err)
+			return
+		}
+		spanSize := int(binary.LittleEndian.Uint32(i32buf[:]))
+		if spanSize < 0 {
+			err = fmt.Errorf("invalid span size from trace file %q: %w", traceFilePath, err)
+			return
+		} else if spanSize == 0 {
+			continue
+		}
+		remainSpanCount--
+		spanBuf := make([]byte, spanSize)
+		if _, err = io.ReadFull(r, spanBuf); nil != err {
+			err = fmt.Errorf("cannot read span data from trace file %q: %w", traceFilePath, err)
+			return
+		}
+		traceSpan := &tracepb.ResourceSpans{}
+		if err = proto.Unmarshal(spanBuf, traceSpan); nil != err {
+			err = fmt.Errorf("cannot unmarshal span data from trace file %q: %w", traceFilePath, err)
+			return
+		}
+		traceRecords = append(traceRecords, traceSpan)
+	}
+	return
+}
+
+func ImportTraceFolder(ctx context.Context, client otlptrace.Client, folderPath string, opts ...ImportOption) (err error) {
+	cfg := importConfig{}
+	for _, opt := range opts {
+		cfg = opt.applyImportOption(cfg)
+	}
 	entries, err := os.ReadDir(folderPath)
 	if err != nil {
 		err = fmt.Errorf("cannot read trace folder %q: %w", folderPath, err)
 		return
 	}
+	var errS []error
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -78,9 +144,16 @@ func ImportTraceFolder(ctx context.Context, client otlptrace.Client, folderPath
 		if (len(fileName) > 1) && ((fileName[0] == '_') || (fileName[0] == '.')) {
 			continue
 		}
-		if err = ImportTraceFile(ctx, client, filepath.Join(folderPath, fileName)); nil != err {
-			return
+		if err = ImportTraceFile(ctx, client, filepath.Join(folderPath, fileName), opts...); nil != err {
+			if !cfg.continueOnError {
+				return
+			}
+			errS = append(errS, fmt.Errorf("cannot import trace file %q: %w", fileName, err))
+			err = nil
 		}
 	}
+	if len(errS) > 0 {
+		err = fmt.Errorf("cannot import %d trace file(s) in folder %q: %w", len(errS), folderPath, errors.Join(errS...))
+	}
 	return
 }
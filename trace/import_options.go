@@ -0,0 +1,82 @@
+package trace
+
+import "time"
+
+// RetryPolicy controls retry/backoff behavior for transient upload errors
+// encountered while importing a trace file. It mirrors the shape of
+// cenkalti/backoff/v4's ExponentialBackOff.
+//
+// The zero value disables retrying: a chunk upload failure aborts the file
+// immediately, matching the behavior before retries were introduced.
+type RetryPolicy struct {
+	InitialInterval     time.Duration
+	MaxInterval         time.Duration
+	Multiplier          float64
+	MaxElapsedTime      time.Duration
+	RandomizationFactor float64
+}
+
+// DefaultRetryPolicy mirrors the defaults of cenkalti/backoff/v4's
+// ExponentialBackOff.
+var DefaultRetryPolicy = RetryPolicy{
+	InitialInterval:     500 * time.Millisecond,
+	MaxInterval:         60 * time.Second,
+	Multiplier:          1.5,
+	MaxElapsedTime:      15 * time.Minute,
+	RandomizationFactor: 0.5,
+}
+
+func (p RetryPolicy) isZero() bool {
+	return p == RetryPolicy{}
+}
+
+type importConfig struct {
+	retryPolicy     RetryPolicy
+	checkpointDir   string
+	continueOnError bool
+}
+
+type ImportOption interface {
+	applyImportOption(cfg importConfig) importConfig
+}
+
+type simpleImportOption struct {
+	fn func(cfg importConfig) importConfig
+}
+
+func newSimpleImportOption(fn func(cfg importConfig) importConfig) ImportOption {
+	return &simpleImportOption{fn: fn}
+}
+
+func (o *simpleImportOption) applyImportOption(cfg importConfig) importConfig {
+	return o.fn(cfg)
+}
+
+// WithRetryPolicy sets the retry/backoff policy used for transient chunk
+// upload failures.
+func WithRetryPolicy(policy RetryPolicy) ImportOption {
+	return newSimpleImportOption(func(cfg importConfig) importConfig {
+		cfg.retryPolicy = policy
+		return cfg
+	})
+}
+
+// WithCheckpointDir enables resumable imports: after each chunk uploads
+// successfully, its byte offset is recorded under dir, keyed by the trace
+// file's path, so a re-run resumes rather than re-sending already-uploaded
+// chunks.
+func WithCheckpointDir(dir string) ImportOption {
+	return newSimpleImportOption(func(cfg importConfig) importConfig {
+		cfg.checkpointDir = dir
+		return cfg
+	})
+}
+
+// WithContinueOnError makes ImportTraceFolder keep walking remaining files
+// after one file fails, instead of aborting the whole folder.
+func WithContinueOnError(continueOnError bool) ImportOption {
+	return newSimpleImportOption(func(cfg importConfig) importConfig {
+		cfg.continueOnError = continueOnError
+		return cfg
+	})
+}
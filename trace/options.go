@@ -55,3 +55,13 @@ func WithProtoBufMarshalOptions(marshalOpts proto.MarshalOptions) Option {
 		return cfg
 	})
 }
+
+// WithCompression sets the codec used to compress each chunk before it is
+// appended to the output file. The codec is recorded alongside the chunk,
+// so it does not need to be known again at import time.
+func WithCompression(codec Codec) Option {
+	return newSimpleOption(func(cfg Config) Config {
+		cfg.compressionCodec = codec
+		return cfg
+	})
+}
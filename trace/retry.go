@@ -0,0 +1,62 @@
+package trace
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uploadWithRetry uploads one chunk's spans, retrying transient failures
+// per policy. A zero policy uploads once with no retry.
+func uploadWithRetry(ctx context.Context, client otlptrace.Client, traceRecords []*tracepb.ResourceSpans, policy RetryPolicy) (err error) {
+	if policy.isZero() {
+		return client.UploadTraces(ctx, traceRecords)
+	}
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     policy.InitialInterval,
+		RandomizationFactor: policy.RandomizationFactor,
+		Multiplier:          policy.Multiplier,
+		MaxInterval:         policy.MaxInterval,
+		MaxElapsedTime:      policy.MaxElapsedTime,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	b.Reset()
+	return backoff.Retry(func() error {
+		uploadErr := client.UploadTraces(ctx, traceRecords)
+		if nil == uploadErr {
+			return nil
+		}
+		if isPermanentUploadError(uploadErr) {
+			return backoff.Permanent(uploadErr)
+		}
+		return uploadErr
+	}, backoff.WithContext(b, ctx))
+}
+
+// httpPermanentStatusPattern matches the HTTP status codes otlptracehttp
+// embeds in its error text (e.g. "failed to send to https://...: 401
+// Unauthorized") that indicate a permanent failure. otlptracehttp does not
+// expose the status code through a typed/GRPCStatus-compatible error, so
+// this is the only way to classify it short of parsing its internals.
+var httpPermanentStatusPattern = regexp.MustCompile(`\b(400|401|403|404|501)\b`)
+
+// isPermanentUploadError classifies an upload error as permanent (retrying
+// would not help) vs. transient, for both the grpc and http/protobuf OTLP
+// transports.
+func isPermanentUploadError(err error) bool {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied, codes.Unimplemented, codes.NotFound:
+			return true
+		default:
+			return false
+		}
+	}
+	return httpPermanentStatusPattern.MatchString(err.Error())
+}
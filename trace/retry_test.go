@@ -0,0 +1,35 @@
+package trace
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsPermanentUploadError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"grpc not found", status.Error(codes.NotFound, "not found"), true},
+		{"grpc unauthenticated", status.Error(codes.Unauthenticated, "nope"), true},
+		{"grpc invalid argument", status.Error(codes.InvalidArgument, "bad request"), true},
+		{"grpc unavailable", status.Error(codes.Unavailable, "retry me"), false},
+		{"grpc deadline exceeded", status.Error(codes.DeadlineExceeded, "timeout"), false},
+		{"http 401", errors.New("failed to send to https://collector: 401 Unauthorized"), true},
+		{"http 400", errors.New("failed to send to https://collector: 400 Bad Request"), true},
+		{"http 503", errors.New("failed to send to https://collector: 503 Service Unavailable"), false},
+		{"http 429", errors.New("failed to send to https://collector: 429 Too Many Requests"), false},
+		{"plain error", errors.New("connection reset by peer"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPermanentUploadError(tc.err); got != tc.want {
+				t.Errorf("isPermanentUploadError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package trace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// exportSampleSpans drives n spans through a real FilesTraceExporter via a
+// TracerProvider, the same way a real instrumented process would, and
+// returns the hour folder it wrote.
+func exportSampleSpans(t *testing.T, baseDir string, n int) string {
+	t.Helper()
+	exporter, err := NewFilesTraceExporter(WithBaseFolderPath(baseDir))
+	if nil != err {
+		t.Fatalf("NewFilesTraceExporter() error = %v", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter)))
+	tracer := tp.Tracer("verify-test")
+	for i := 0; i < n; i++ {
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+	}
+	if err := tp.Shutdown(context.Background()); nil != err {
+		t.Fatalf("tp.Shutdown() error = %v", err)
+	}
+	entries, err := os.ReadDir(baseDir)
+	if (nil != err) || (len(entries) != 1) {
+		t.Fatalf("expected exactly 1 hour folder in %q, got %v (err=%v)", baseDir, entries, err)
+	}
+	return filepath.Join(baseDir, entries[0].Name())
+}
+
+func TestVerifyFolderOK(t *testing.T) {
+	folderPath := exportSampleSpans(t, t.TempDir(), 5)
+
+	issues, err := VerifyFolder(folderPath)
+	if nil != err {
+		t.Fatalf("VerifyFolder() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("VerifyFolder() issues = %v, want none", issues)
+	}
+}
+
+func TestVerifyFolderDetectsCorruption(t *testing.T) {
+	folderPath := exportSampleSpans(t, t.TempDir(), 5)
+
+	entries, err := os.ReadDir(folderPath)
+	if nil != err {
+		t.Fatalf("os.ReadDir() error = %v", err)
+	}
+	corrupted := false
+	for _, entry := range entries {
+		name := entry.Name()
+		if (len(name) > 0) && (name[0] == '_') {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(folderPath, name), []byte{0x01, 0x02, 0x03}, 0o600); nil != err {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+		corrupted = true
+	}
+	if !corrupted {
+		t.Fatal("expected at least one output file to corrupt")
+	}
+
+	issues, err := VerifyFolder(folderPath)
+	if nil != err {
+		t.Fatalf("VerifyFolder() error = %v", err)
+	}
+	if len(issues) == 0 {
+		t.Fatal("VerifyFolder() found no issues for a truncated/corrupted output file")
+	}
+}